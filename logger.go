@@ -0,0 +1,108 @@
+package negroni
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"os"
+	"text/template"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultLoggerFormat is the format used by NewLogger.
+const DefaultLoggerFormat = "{{.StartTime}} | {{.Status}} | {{.Duration}} | {{.Hostname}} | {{.Method}} {{.Path}}\n"
+
+// DefaultLoggerDateFormat is the date format used for LoggerEntry.StartTime.
+const DefaultLoggerDateFormat = "02/Jan/2006:15:04:05 -0700"
+
+// ALogger is the interface Logger writes rendered log lines to.
+type ALogger interface {
+	Println(v ...interface{})
+	Printf(format string, v ...interface{})
+}
+
+// LoggerEntry holds the data rendered by Logger's format template.
+type LoggerEntry struct {
+	StartTime string
+	Status    int
+	Size      int
+	Duration  time.Duration
+	Hostname  string
+	Method    string
+	Path      string
+	// RequestID comes from the context via NewContextWithRequestID, if set.
+	RequestID string
+}
+
+// Logger is a Handler that logs each request once the downstream handlers
+// have finished.
+type Logger struct {
+	ALogger
+	dateFormat string
+	template   *template.Template
+}
+
+// NewLogger returns a new Logger instance writing to stdout with the
+// DefaultLoggerFormat.
+func NewLogger() *Logger {
+	logger := &Logger{
+		ALogger:    log.New(os.Stdout, "[negroni] ", 0),
+		dateFormat: DefaultLoggerDateFormat,
+	}
+	logger.SetFormat(DefaultLoggerFormat)
+	return logger
+}
+
+// SetFormat replaces the template used to render each log line.
+func (l *Logger) SetFormat(format string) {
+	l.template = template.Must(template.New("negroni_logger").Parse(format))
+}
+
+func (l *Logger) ServeHTTP(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+	start := time.Now()
+
+	next(ctx, rw, r)
+
+	status, size := 0, 0
+	if res, ok := rw.(ResponseWriter); ok {
+		status = res.Status()
+		size = res.Size()
+	}
+
+	entry := LoggerEntry{
+		StartTime: start.Format(l.dateFormat),
+		Status:    status,
+		Size:      size,
+		Duration:  time.Since(start),
+		Hostname:  r.Host,
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		RequestID: RequestIDFromContext(ctx),
+	}
+
+	buf := &bytes.Buffer{}
+	if err := l.template.Execute(buf, entry); err != nil {
+		l.Printf("negroni: failed to render log entry: %v", err)
+		return
+	}
+	l.Printf("%s", buf.String())
+}
+
+type requestIDKeyType int
+
+// requestIDKey is the context key Logger reads the request ID from.
+const requestIDKey requestIDKeyType = 0
+
+// NewContextWithRequestID returns a copy of ctx carrying id.
+func NewContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFromContext returns the request id stored by
+// NewContextWithRequestID, or "" if none is set.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
@@ -0,0 +1,60 @@
+package negroni
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestLoggerLogsStatusAndPath(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewLogger()
+	logger.ALogger = log.New(buf, "", 0)
+
+	n := New(logger)
+	n.UseHandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusTeapot)
+	})
+
+	req, _ := http.NewRequest("GET", "http://example.com/brew", nil)
+	n.ServeHTTP(httptest.NewRecorder(), req)
+
+	out := buf.String()
+	if !strings.Contains(out, "418") {
+		t.Errorf("expected log line to contain status 418, got %q", out)
+	}
+	if !strings.Contains(out, "/brew") {
+		t.Errorf("expected log line to contain path /brew, got %q", out)
+	}
+}
+
+func TestLoggerEntrySize(t *testing.T) {
+	logger := NewLogger()
+	logger.SetFormat("{{.Size}}")
+
+	buf := &bytes.Buffer{}
+	logger.ALogger = log.New(buf, "", 0)
+
+	n := New(logger)
+	n.UseHandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Write([]byte("hello"))
+	})
+
+	req, _ := http.NewRequest("GET", "http://example.com/brew", nil)
+	n.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !strings.Contains(buf.String(), "5") {
+		t.Errorf("expected log line to contain response size 5, got %q", buf.String())
+	}
+}
+
+func TestRequestIDRoundTrip(t *testing.T) {
+	ctx := NewContextWithRequestID(context.Background(), "req-1")
+	expect(t, RequestIDFromContext(ctx), "req-1")
+	expect(t, RequestIDFromContext(context.Background()), "")
+}
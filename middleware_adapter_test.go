@@ -0,0 +1,56 @@
+package negroni
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestMiddlewareFallsThroughToNext(t *testing.T) {
+	result := ""
+	n := New(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		result += "n"
+		next(ctx, rw, r)
+	}))
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		result += "next"
+	})
+
+	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+	n.Middleware(next).ServeHTTP(httptest.NewRecorder(), req)
+
+	expect(t, result, "nnext")
+}
+
+func TestUnwrapResponseWriterReusesExisting(t *testing.T) {
+	response := httptest.NewRecorder()
+	wrapped := NewResponseWriter(response)
+
+	got := UnwrapResponseWriter(wrapped)
+
+	if got != wrapped {
+		t.Errorf("expected UnwrapResponseWriter to reuse the existing ResponseWriter, got a new one")
+	}
+}
+
+func TestServeHTTPNextDoesNotDoubleWrap(t *testing.T) {
+	n := New(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		rw.WriteHeader(http.StatusAccepted)
+		next(ctx, rw, r)
+	}))
+
+	response := httptest.NewRecorder()
+	wrapped := NewResponseWriter(response)
+	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+
+	called := false
+	n.ServeHTTPNext(wrapped, req, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		called = true
+		expect(t, rw.(ResponseWriter).Status(), http.StatusAccepted)
+	}))
+
+	expect(t, called, true)
+}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sync/atomic"
 	"golang.org/x/net/context"
 )
 
@@ -73,26 +74,95 @@ func WrapCH(handler ContextHandler) Handler {
 // the Use and UseHandler methods.
 type Negroni struct {
 	middleware middleware
+	handlers   []Handler
+	served     int32
 }
 
 // New returns a new Negroni instance with no middleware preconfigured.
 func New(handlers ...Handler) *Negroni {
+	hs := append([]Handler{}, handlers...)
 	return &Negroni{
-		middleware: build(handlers),
+		middleware: build(hs),
+		handlers:   hs,
 	}
 }
 
+// Classic returns a new Negroni instance with Recovery, Logger, and Static
+// (serving "public") already in the stack.
+func Classic() *Negroni {
+	return New(NewRecovery(), NewLogger(), NewStatic(http.Dir("public")))
+}
+
 func (n *Negroni) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	n.middleware.ServeHTTPC(context.Background(), NewResponseWriter(rw), r)
+	atomic.StoreInt32(&n.served, 1)
+	n.middleware.ServeHTTPC(context.Background(), UnwrapResponseWriter(rw), r)
 }
 
 func (n *Negroni) ServeHTTPC(ctx context.Context, rw http.ResponseWriter, r *http.Request) {
-	n.middleware.ServeHTTPC(ctx, NewResponseWriter(rw), r)
+	atomic.StoreInt32(&n.served, 1)
+	n.middleware.ServeHTTPC(ctx, UnwrapResponseWriter(rw), r)
+}
+
+// ServeHTTPNext lets a *Negroni be mounted as a single Handler inside
+// another chain, falling through to next once its own stack ends.
+func (n *Negroni) ServeHTTPNext(rw http.ResponseWriter, r *http.Request, next http.Handler) {
+	atomic.StoreInt32(&n.served, 1)
+	n.Middleware(next).ServeHTTP(rw, r)
+}
+
+// Middleware returns an http.Handler that runs the Negroni stack and falls
+// through to next once the stack ends, instead of the internal void
+// middleware, so a *Negroni composes under another router.
+func (n *Negroni) Middleware(next http.Handler) http.Handler {
+	tail := voidMiddleware()
+	if next != nil {
+		voidTail := voidMiddleware()
+		tail = middleware{Wrap(next), &voidTail}
+	}
+	chain := buildChain(n.handlers, tail)
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		chain.ServeHTTPC(context.Background(), UnwrapResponseWriter(rw), r)
+	})
+}
+
+// UnwrapResponseWriter returns rw as a negroni ResponseWriter, reusing it
+// as-is if it already implements the interface instead of wrapping it twice.
+func UnwrapResponseWriter(rw http.ResponseWriter) ResponseWriter {
+	if nrw, ok := rw.(ResponseWriter); ok {
+		return nrw
+	}
+	return NewResponseWriter(rw)
 }
 
 // Use adds a Handler onto the middleware stack. Handlers are invoked in the order they are added to a Negroni.
+//
+// Deprecated: mutating a Negroni's stack once it has started serving
+// requests races with the goroutines reading that stack. Prefer assembling
+// the full stack up front with New, or deriving a new stack with With
+// before the first ServeHTTP/ServeHTTPC call.
 func (n *Negroni) Use(handler Handler) {
-	appendMiddleware(&(n.middleware), handler)
+	if atomic.LoadInt32(&n.served) != 0 {
+		log.Println("negroni: Use called on a Negroni that has already served requests; this is a data race under concurrent use, see Negroni.With")
+	}
+	n.handlers = append(n.handlers, handler)
+	n.middleware = build(n.handlers)
+}
+
+// With returns a new Negroni whose stack is the receiver's handlers
+// followed by the given handlers. The receiver is left untouched, so a
+// base Negroni can be shared as the common prefix of several divergent
+// chains, e.g. one per subrouter.
+func (n *Negroni) With(handlers ...Handler) *Negroni {
+	return NewWith(n, handlers...)
+}
+
+// NewWith returns a new Negroni whose stack is base's handlers followed by
+// the given handlers. base is not modified.
+func NewWith(base *Negroni, handlers ...Handler) *Negroni {
+	combined := append([]Handler{}, base.handlers...)
+	combined = append(combined, handlers...)
+	return New(combined...)
 }
 
 // UseFunc adds a Negroni-style handler function onto the middleware stack.
@@ -130,64 +200,26 @@ func (n *Negroni) Run(addr string) {
 
 // Returns a list of all the handlers in the current Negroni middleware chain.
 func (n *Negroni) Handlers() []Handler {
-	var handlers []Handler
-
-	curr := &(n.middleware)
-	for !isVoidMiddleware(curr) {
-		handlers = append(handlers, curr.handler)
-		curr = curr.next
-	}
-
-	return handlers
+	return append([]Handler{}, n.handlers...)
 }
 
 func build(handlers []Handler) middleware {
-	var next middleware
+	return buildChain(handlers, voidMiddleware())
+}
 
+// buildChain builds a middleware chain out of handlers terminated by tail.
+func buildChain(handlers []Handler, tail middleware) middleware {
 	if len(handlers) == 0 {
-		return voidMiddleware()
-	} else if len(handlers) > 1 {
-		next = build(handlers[1:])
-	} else {
-		next = voidMiddleware()
+		return tail
 	}
 
+	next := buildChain(handlers[1:], tail)
 	return middleware{handlers[0], &next}
 }
 
-func appendMiddleware(m *middleware, h Handler) {
-	var pre *middleware
-	curr := m
-	for !isVoidMiddleware(curr) {
-		pre = curr
-		curr = curr.next
-	}
-
-	if pre == nil {
-		m.handler = h
-		next := voidMiddleware()
-		m.next = &next
-	} else {
-		pre.next = &middleware{h, curr}
-	}
-}
-
 func voidMiddleware() middleware {
 	return middleware{
 		HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {}),
 		&middleware{},
 	}
 }
-
-func isVoidMiddleware(m *middleware) bool {
-	if m != nil {
-		next := m.next
-		if next.handler == nil && next.next == nil {
-			return true
-		} else {
-			return false
-		}
-	} else {
-		return false
-	}
-}
@@ -5,6 +5,8 @@ import (
 	"net/http/httptest"
 	"reflect"
 	"testing"
+
+	"golang.org/x/net/context"
 )
 
 /* Test Helpers */
@@ -30,14 +32,14 @@ func TestNegroniServeHTTP(t *testing.T) {
 	response := httptest.NewRecorder()
 
 	n := New()
-	n.Use(HandlerFunc(func(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	n.Use(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
 		result += "foo"
-		next(rw, r)
+		next(ctx, rw, r)
 		result += "ban"
 	}))
-	n.UseFunc(func(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	n.UseFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
 		result += "bar"
-		next(rw, r)
+		next(ctx, rw, r)
 		result += "baz"
 	})
 	n.UseHandler(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
@@ -58,7 +60,7 @@ func TestServeHTTPResponseWriter(t *testing.T) {
 	response := httptest.NewRecorder()
 
 	n := New()
-	n.Use(HandlerFunc(func(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	n.Use(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
 		if prw, ok := rw.(*responseWriter); ok {
 			if _, ok := prw.ResponseWriter.(ResponseWriter); ok {
 				t.Errorf("%s: prw.ResponseWriter should not be ResponseWriter.", r.URL.String())
@@ -66,6 +68,7 @@ func TestServeHTTPResponseWriter(t *testing.T) {
 		} else {
 			t.Errorf("%s: rw should be *responseWriter.", r.URL.String())
 		}
+		next(ctx, rw, r)
 	}))
 
 	req, _ := http.NewRequest("GET", "http://http.ResponseWriter", nil)
@@ -74,7 +77,7 @@ func TestServeHTTPResponseWriter(t *testing.T) {
 	n.ServeHTTP(NewResponseWriter(response), req2)
 }
 
-// Ensures that a Negroni middleware chain 
+// Ensures that a Negroni middleware chain
 // can correctly return all of its handlers.
 func TestHandlers(t *testing.T) {
 	response := httptest.NewRecorder()
@@ -82,17 +85,48 @@ func TestHandlers(t *testing.T) {
 	handlers := n.Handlers()
 	expect(t, 0, len(handlers))
 
-	n.Use(HandlerFunc(func(rw http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+	n.Use(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
 		rw.WriteHeader(http.StatusOK)
 	}))
 
-	// Expects the length of handlers to be exactly 1 
+	// Expects the length of handlers to be exactly 1
 	// after adding exactly one handler to the middleware chain
 	handlers = n.Handlers()
 	expect(t, 1, len(handlers))
 
 	// Ensures that the first handler that is in sequence behaves
 	// exactly the same as the one that was registered earlier
-	handlers[0].ServeHTTP(response, (*http.Request)(nil), nil)
+	handlers[0].ServeHTTP(context.Background(), response, (*http.Request)(nil), nil)
 	expect(t, response.Code, http.StatusOK)
-}
\ No newline at end of file
+}
+
+// Ensures that With derives independent chains from a shared base without
+// the base or any sibling chain observing each other's handlers.
+func TestWithDoesNotShareChain(t *testing.T) {
+	base := New(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		next(ctx, rw, r)
+	}))
+	expect(t, 1, len(base.Handlers()))
+
+	left := base.With(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		next(ctx, rw, r)
+	}))
+	right := base.With(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		next(ctx, rw, r)
+	}), HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		next(ctx, rw, r)
+	}))
+
+	// base is untouched by deriving left and right from it
+	expect(t, 1, len(base.Handlers()))
+	expect(t, 2, len(left.Handlers()))
+	expect(t, 3, len(right.Handlers()))
+
+	// mutating one derived chain must not leak into its sibling or the base
+	left.Use(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		next(ctx, rw, r)
+	}))
+	expect(t, 1, len(base.Handlers()))
+	expect(t, 3, len(left.Handlers()))
+	expect(t, 3, len(right.Handlers()))
+}
@@ -0,0 +1,98 @@
+package negroni
+
+import (
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+// Predicate reports whether a request should be routed through a
+// conditionally-applied Handler. See UseIf.
+type Predicate func(r *http.Request) bool
+
+// ContextPredicate is a Predicate that can also inspect the request's
+// context.Context. See UseIfContext.
+type ContextPredicate func(ctx context.Context, r *http.Request) bool
+
+// PathPrefix returns a Predicate matching requests whose URL path starts
+// with prefix.
+func PathPrefix(prefix string) Predicate {
+	return func(r *http.Request) bool {
+		return strings.HasPrefix(r.URL.Path, prefix)
+	}
+}
+
+// Methods returns a Predicate matching requests using one of the given
+// HTTP methods.
+func Methods(methods ...string) Predicate {
+	set := make(map[string]struct{}, len(methods))
+	for _, m := range methods {
+		set[strings.ToUpper(m)] = struct{}{}
+	}
+	return func(r *http.Request) bool {
+		_, ok := set[r.Method]
+		return ok
+	}
+}
+
+// Host returns a Predicate matching requests whose Host header equals host.
+func Host(host string) Predicate {
+	return func(r *http.Request) bool {
+		return r.Host == host
+	}
+}
+
+// And returns a Predicate matching when every one of preds matches.
+func And(preds ...Predicate) Predicate {
+	return func(r *http.Request) bool {
+		for _, p := range preds {
+			if !p(r) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns a Predicate matching when any one of preds matches.
+func Or(preds ...Predicate) Predicate {
+	return func(r *http.Request) bool {
+		for _, p := range preds {
+			if p(r) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a Predicate matching when pred does not.
+func Not(pred Predicate) Predicate {
+	return func(r *http.Request) bool {
+		return !pred(r)
+	}
+}
+
+// UseIf adds h onto the middleware stack, but only invokes it for requests
+// matching pred; otherwise the chain skips straight to the next Handler.
+func (n *Negroni) UseIf(pred Predicate, h Handler) {
+	n.Use(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		if pred(r) {
+			h.ServeHTTP(ctx, rw, r, next)
+			return
+		}
+		next(ctx, rw, r)
+	}))
+}
+
+// UseIfContext behaves like UseIf, but pred may also inspect ctx.
+func (n *Negroni) UseIfContext(pred ContextPredicate, h Handler) {
+	n.Use(HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		if pred(ctx, r) {
+			h.ServeHTTP(ctx, rw, r, next)
+			return
+		}
+		next(ctx, rw, r)
+	}))
+}
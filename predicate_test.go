@@ -0,0 +1,40 @@
+package negroni
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestUseIfOnlyRunsWhenPredicateMatches(t *testing.T) {
+	result := ""
+	n := New()
+	n.UseIf(PathPrefix("/api"), HandlerFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		result += "api"
+		next(ctx, rw, r)
+	}))
+	n.UseFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		result += "next"
+	})
+
+	req, _ := http.NewRequest("GET", "http://example.com/web", nil)
+	n.ServeHTTP(httptest.NewRecorder(), req)
+	expect(t, result, "next")
+
+	result = ""
+	req, _ = http.NewRequest("GET", "http://example.com/api/users", nil)
+	n.ServeHTTP(httptest.NewRecorder(), req)
+	expect(t, result, "apinext")
+}
+
+func TestPredicateCombinators(t *testing.T) {
+	isAPI := PathPrefix("/api")
+	isPost := Methods("POST")
+
+	req, _ := http.NewRequest("POST", "http://example.com/api/users", nil)
+	expect(t, And(isAPI, isPost)(req), true)
+	expect(t, Or(isPost, PathPrefix("/web"))(req), true)
+	expect(t, Not(isAPI)(req), false)
+}
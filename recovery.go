@@ -0,0 +1,97 @@
+package negroni
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"runtime"
+
+	"golang.org/x/net/context"
+)
+
+// NoPrintStackBodyString is the body written to the response when a panic is
+// recovered and PrintStack is false.
+const NoPrintStackBodyString = "500 Internal Server Error"
+
+// PanicInformation is passed to a Recovery's PanicHandlerFunc.
+type PanicInformation struct {
+	RecoveredPanic interface{}
+	Stack          []byte
+	Request        *http.Request
+
+	// Context is the request's context.Context at the time of the panic.
+	Context context.Context
+}
+
+// StackAsString returns a printable version of the stack.
+func (p *PanicInformation) StackAsString() string {
+	return string(p.Stack)
+}
+
+// RequestDescription returns a printable description of the url.
+func (p *PanicInformation) RequestDescription() string {
+	var queryOutput string
+	if p.Request.URL.RawQuery != "" {
+		queryOutput = "?" + p.Request.URL.RawQuery
+	}
+	return fmt.Sprintf("%s %s%s", p.Request.Method, p.Request.URL.Path, queryOutput)
+}
+
+// Recovery is a Handler that recovers from panics in downstream handlers
+// and writes a 500 response instead of letting them reach net/http.
+type Recovery struct {
+	Logger     *log.Logger
+	PrintStack bool
+
+	// StackAll, if true, collects goroutines other than the current one too.
+	StackAll bool
+	// StackSize is the size, in bytes, of the buffer used for the stack trace.
+	StackSize int
+
+	// PanicHandlerFunc, if set, is called with information about the panic.
+	PanicHandlerFunc func(*PanicInformation)
+}
+
+// NewRecovery returns a new Recovery instance with sensible defaults.
+func NewRecovery() *Recovery {
+	return &Recovery{
+		Logger:     log.New(os.Stdout, "[negroni] ", 0),
+		PrintStack: true,
+		StackAll:   false,
+		StackSize:  1024 * 8,
+	}
+}
+
+func (rec *Recovery) ServeHTTP(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+	defer func() {
+		if err := recover(); err != nil {
+			rw.WriteHeader(http.StatusInternalServerError)
+
+			stack := make([]byte, rec.StackSize)
+			stack = stack[:runtime.Stack(stack, rec.StackAll)]
+
+			f := "PANIC: %s\n%s"
+			rec.Logger.Printf(f, err, stack)
+
+			info := &PanicInformation{
+				RecoveredPanic: err,
+				Stack:          stack,
+				Request:        r,
+				Context:        ctx,
+			}
+
+			if rec.PrintStack {
+				fmt.Fprintf(rw, f, err, stack)
+			} else {
+				fmt.Fprint(rw, NoPrintStackBodyString)
+			}
+
+			if rec.PanicHandlerFunc != nil {
+				rec.PanicHandlerFunc(info)
+			}
+		}
+	}()
+
+	next(ctx, rw, r)
+}
@@ -0,0 +1,48 @@
+package negroni
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestRecoveryWritesInternalServerError(t *testing.T) {
+	response := httptest.NewRecorder()
+	rec := NewRecovery()
+	rec.Logger = log.New(httptest.NewRecorder(), "", 0)
+
+	n := New(rec)
+	n.UseFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		panic("oh no")
+	})
+
+	n.ServeHTTP(response, (*http.Request)(nil))
+
+	expect(t, response.Code, http.StatusInternalServerError)
+}
+
+func TestRecoveryPanicHandlerFuncReceivesStack(t *testing.T) {
+	response := httptest.NewRecorder()
+	rec := NewRecovery()
+	rec.Logger = log.New(httptest.NewRecorder(), "", 0)
+	rec.PrintStack = false
+
+	var got *PanicInformation
+	rec.PanicHandlerFunc = func(info *PanicInformation) {
+		got = info
+	}
+
+	n := New(rec)
+	n.UseFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		panic("boom")
+	})
+
+	n.ServeHTTP(response, (*http.Request)(nil))
+
+	refute(t, got, nil)
+	expect(t, got.RecoveredPanic, "boom")
+	refute(t, len(got.Stack), 0)
+}
@@ -0,0 +1,76 @@
+package negroni
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// ResponseWriter is a wrapper around http.ResponseWriter that provides
+// extra information about the response. Middleware that needs to inspect
+// or react to the response (logging, recovery, ...) should use this
+// instead of the raw http.ResponseWriter.
+type ResponseWriter interface {
+	http.ResponseWriter
+	http.Flusher
+
+	// Status returns the status code of the response, or 0 if the response
+	// has not been written yet.
+	Status() int
+	// Size returns the number of bytes already written to the response body.
+	Size() int
+	// Written returns whether the response has been written to yet.
+	Written() bool
+}
+
+// NewResponseWriter wraps rw in a ResponseWriter.
+func NewResponseWriter(rw http.ResponseWriter) ResponseWriter {
+	return &responseWriter{ResponseWriter: rw}
+}
+
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (rw *responseWriter) WriteHeader(s int) {
+	rw.status = s
+	rw.ResponseWriter.WriteHeader(s)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if !rw.Written() {
+		rw.WriteHeader(http.StatusOK)
+	}
+	size, err := rw.ResponseWriter.Write(b)
+	rw.size += size
+	return size, err
+}
+
+func (rw *responseWriter) Status() int {
+	return rw.status
+}
+
+func (rw *responseWriter) Size() int {
+	return rw.size
+}
+
+func (rw *responseWriter) Written() bool {
+	return rw.status != 0
+}
+
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("negroni: underlying ResponseWriter doesn't support http.Hijacker")
+	}
+	return hijacker.Hijack()
+}
@@ -0,0 +1,87 @@
+package negroni
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"golang.org/x/net/context"
+)
+
+// Static is a Handler that serves files out of a directory/filesystem,
+// falling through to next if the requested file does not exist.
+type Static struct {
+	// Dir is the directory to serve static files from.
+	Dir http.FileSystem
+	// Prefix is an optional path prefix to serve the content under.
+	Prefix string
+	// IndexFile is served for directory requests; "" disables it.
+	IndexFile string
+}
+
+// NewStatic returns a new Static instance that serves files out of dir.
+func NewStatic(dir http.FileSystem) *Static {
+	return &Static{
+		Dir:       dir,
+		Prefix:    "",
+		IndexFile: "index.html",
+	}
+}
+
+func (s *Static) ServeHTTP(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		next(ctx, rw, r)
+		return
+	}
+
+	file := r.URL.Path
+	if s.Prefix != "" {
+		if !strings.HasPrefix(file, s.Prefix) {
+			next(ctx, rw, r)
+			return
+		}
+		file = strings.TrimPrefix(file, s.Prefix)
+		if file != "" && file[0] != '/' {
+			next(ctx, rw, r)
+			return
+		}
+	}
+
+	f, err := s.Dir.Open(file)
+	if err != nil {
+		next(ctx, rw, r)
+		return
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		next(ctx, rw, r)
+		return
+	}
+
+	if fi.IsDir() {
+		if s.IndexFile == "" {
+			next(ctx, rw, r)
+			return
+		}
+
+		file = path.Join(file, s.IndexFile)
+		f, err = s.Dir.Open(file)
+		if err != nil {
+			next(ctx, rw, r)
+			return
+		}
+		defer f.Close()
+
+		fi, err = f.Stat()
+		if err != nil || fi.IsDir() {
+			next(ctx, rw, r)
+			return
+		}
+	}
+
+	// http.ServeContent takes care of Content-Type sniffing via
+	// mime.TypeByExtension and honors If-Modified-Since for us.
+	http.ServeContent(rw, r, file, fi.ModTime(), f)
+}
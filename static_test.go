@@ -0,0 +1,52 @@
+package negroni
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestStaticServesExistingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "negroni-static")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	n := New(NewStatic(http.Dir(dir)))
+	req, _ := http.NewRequest("GET", "http://example.com/hello.txt", nil)
+	response := httptest.NewRecorder()
+
+	n.ServeHTTP(response, req)
+
+	expect(t, response.Code, http.StatusOK)
+	expect(t, response.Body.String(), "hello")
+}
+
+func TestStaticFallsThroughWhenMissing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "negroni-static")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	result := ""
+	n := New(NewStatic(http.Dir(dir)))
+	n.UseFunc(func(ctx context.Context, rw http.ResponseWriter, r *http.Request, next ContextHandlerFunc) {
+		result = "next"
+	})
+
+	req, _ := http.NewRequest("GET", "http://example.com/missing.txt", nil)
+	n.ServeHTTP(httptest.NewRecorder(), req)
+
+	expect(t, result, "next")
+}